@@ -0,0 +1,183 @@
+// Package admin exposes a REST API for inspecting and reshaping a running
+// cluster's topology without requiring a restart.
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/senarukana/sky/skyd"
+)
+
+//------------------------------------------------------------------------------
+//
+// Typedefs
+//
+//------------------------------------------------------------------------------
+
+// Router dispatches cluster administration requests to the appropriate
+// Cluster methods and serializes the results as JSON.
+type Router struct {
+	cluster *skyd.Cluster
+	mux     *http.ServeMux
+}
+
+//------------------------------------------------------------------------------
+//
+// Constructor
+//
+//------------------------------------------------------------------------------
+
+// Creates a new admin router for the given cluster.
+func NewRouter(cluster *skyd.Cluster) *Router {
+	r := &Router{cluster: cluster, mux: http.NewServeMux()}
+	r.mux.HandleFunc("/cluster", r.handleCluster)
+	r.mux.HandleFunc("/cluster/groups", r.handleGroups)
+	r.mux.HandleFunc("/cluster/groups/", r.handleGroup)
+	r.mux.HandleFunc("/cluster/nodes/", r.handleNode)
+	return r
+}
+
+//------------------------------------------------------------------------------
+//
+// Methods
+//
+//------------------------------------------------------------------------------
+
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mux.ServeHTTP(w, req)
+}
+
+//--------------------------------------
+// GET /cluster
+//--------------------------------------
+
+func (r *Router) handleCluster(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, http.StatusOK, r.cluster.Serialize())
+}
+
+//--------------------------------------
+// GET/POST /cluster/groups
+//--------------------------------------
+
+func (r *Router) handleGroups(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, r.cluster.Serialize())
+
+	case http.MethodPost:
+		var params struct {
+			Id string `json:"id"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&params); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := r.cluster.AddNodeGroup(skyd.NewNodeGroup(params.Id)); err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		writeJSON(w, http.StatusCreated, r.cluster.Serialize())
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+//--------------------------------------
+// DELETE /cluster/groups/:id
+// POST   /cluster/groups/:id/nodes
+//--------------------------------------
+
+func (r *Router) handleGroup(w http.ResponseWriter, req *http.Request) {
+	path := strings.TrimPrefix(req.URL.Path, "/cluster/groups/")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) == 0 || parts[0] == "" {
+		http.NotFound(w, req)
+		return
+	}
+	groupId := parts[0]
+
+	// POST /cluster/groups/:id/nodes
+	if len(parts) == 2 && parts[1] == "nodes" && req.Method == http.MethodPost {
+		group := r.cluster.GetNodeGroup(groupId)
+		if group == nil {
+			http.Error(w, skyd.NodeGroupNotFoundError.Error(), http.StatusNotFound)
+			return
+		}
+
+		var params struct {
+			Id   string `json:"id"`
+			Host string `json:"host"`
+			Port uint   `json:"port"`
+			Rack string `json:"rack"`
+			DC   string `json:"dc"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&params); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		node := skyd.NewNodeWithPlacement(params.Id, params.Host, params.Port, params.Rack, params.DC)
+		if err := r.cluster.AddNode(node, group); err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		writeJSON(w, http.StatusCreated, r.cluster.Serialize())
+		return
+	}
+
+	// DELETE /cluster/groups/:id
+	if len(parts) == 1 && req.Method == http.MethodDelete {
+		group := r.cluster.GetNodeGroup(groupId)
+		if group == nil {
+			http.Error(w, skyd.NodeGroupNotFoundError.Error(), http.StatusNotFound)
+			return
+		}
+		if err := r.cluster.RemoveNodeGroup(group); err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		writeJSON(w, http.StatusOK, r.cluster.Serialize())
+		return
+	}
+
+	http.NotFound(w, req)
+}
+
+//--------------------------------------
+// DELETE /cluster/nodes/:id
+//--------------------------------------
+
+func (r *Router) handleNode(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodDelete {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	nodeId := strings.TrimPrefix(req.URL.Path, "/cluster/nodes/")
+	node, _ := r.cluster.GetNode(nodeId)
+	if node == nil {
+		http.Error(w, skyd.NodeNotFoundError.Error(), http.StatusNotFound)
+		return
+	}
+	if err := r.cluster.RemoveNode(node); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	writeJSON(w, http.StatusOK, r.cluster.Serialize())
+}
+
+//--------------------------------------
+// Helpers
+//--------------------------------------
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,87 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/senarukana/sky/skyd"
+)
+
+func newTestCluster(t *testing.T) *skyd.Cluster {
+	c, err := skyd.NewCluster("127.0.0.1:0", "", "")
+	if err != nil {
+		t.Fatalf("NewCluster returned error: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := c.Shutdown(); err != nil {
+			t.Errorf("Shutdown returned error: %v", err)
+		}
+	})
+	return c
+}
+
+func TestGetCluster(t *testing.T) {
+	c := newTestCluster(t)
+	r := NewRouter(c)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/cluster", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestPostGroupThenAddNode(t *testing.T) {
+	c := newTestCluster(t)
+	r := NewRouter(c)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/cluster/groups", strings.NewReader(`{"id":"g0"}`)))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201 creating group, got %d", w.Code)
+	}
+	if c.GetNodeGroup("g0") == nil {
+		t.Fatalf("expected group g0 to exist")
+	}
+
+	w = httptest.NewRecorder()
+	body := `{"id":"n0","host":"127.0.0.1","port":9000,"rack":"r1"}`
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/cluster/groups/g0/nodes", strings.NewReader(body)))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201 adding node, got %d: %s", w.Code, w.Body.String())
+	}
+
+	node, _ := c.GetNode("n0")
+	if node == nil || node.Rack() != "r1" {
+		t.Fatalf("expected node n0 with rack r1, got %+v", node)
+	}
+}
+
+func TestDeleteGroupRefusedWhileOwningTablets(t *testing.T) {
+	c := newTestCluster(t)
+	c.AddNodeGroup(skyd.NewNodeGroup("g0"))
+	// The local node's own gossip join created the "auto" group first, which
+	// took every tablet; rebalance so that g0 owns its share of the ring.
+	c.Rebalance()
+	r := NewRouter(c)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/cluster/groups/g0", nil))
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409 removing a group that still owns tablets, got %d", w.Code)
+	}
+}
+
+func TestDeleteUnknownNode(t *testing.T) {
+	c := newTestCluster(t)
+	r := NewRouter(c)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/cluster/nodes/missing", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 deleting unknown node, got %d", w.Code)
+	}
+}
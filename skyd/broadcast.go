@@ -0,0 +1,148 @@
+package skyd
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+//------------------------------------------------------------------------------
+//
+// Globals
+//
+//------------------------------------------------------------------------------
+
+// defaultBroadcastParallelism bounds how many nodes are contacted
+// concurrently by Broadcast when Cluster.BroadcastParallelism is unset
+// (as with a Cluster built directly via a struct literal rather than
+// NewCluster).
+const defaultBroadcastParallelism = 32
+
+var BroadcastQuorumNotReachedError = errors.New("Broadcast did not reach quorum")
+var BroadcastNoSuccessError = errors.New("Broadcast had no successful responses")
+
+//------------------------------------------------------------------------------
+//
+// Typedefs
+//
+//------------------------------------------------------------------------------
+
+// BroadcastMode determines when Broadcast is satisfied and may stop
+// waiting on outstanding nodes.
+type BroadcastMode int
+
+const (
+	// FirstSuccess returns as soon as a single node succeeds.
+	FirstSuccess BroadcastMode = iota
+
+	// Quorum returns once a strict majority of nodes have succeeded.
+	Quorum
+
+	// All waits for every node to respond.
+	All
+)
+
+// BroadcastResult pairs a node with the outcome of calling it.
+type BroadcastResult struct {
+	Node  *Node
+	Value interface{}
+	Err   error
+}
+
+//------------------------------------------------------------------------------
+//
+// Methods
+//
+//------------------------------------------------------------------------------
+
+// Calls fn concurrently for every live node in the cluster and aggregates
+// the results according to mode. Nodes that gossip health has marked dead
+// are skipped rather than contacted, so the query planner doesn't wait on
+// members known to be down. Pending calls are cancelled, via the context
+// passed to fn's goroutine, as soon as mode's condition is satisfied. This
+// is the building block for fanning out query preparation, schema
+// propagation, and health probes across the cluster instead of iterating
+// over groups sequentially.
+func (c *Cluster) Broadcast(fn func(ctx context.Context, node *Node) (interface{}, error), mode BroadcastMode) ([]*BroadcastResult, error) {
+	c.mutex.RLock()
+	all := []*Node{}
+	for _, group := range c.groups {
+		all = append(all, group.nodes...)
+	}
+	c.mutex.RUnlock()
+
+	nodes := c.aliveNodes(all)
+
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	parallelism := c.BroadcastParallelism
+	if parallelism <= 0 {
+		parallelism = defaultBroadcastParallelism
+	}
+	sem := make(chan struct{}, parallelism)
+	results := make(chan *BroadcastResult, len(nodes))
+
+	var wg sync.WaitGroup
+	for _, node := range nodes {
+		wg.Add(1)
+		go func(node *Node) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				results <- &BroadcastResult{Node: node, Err: ctx.Err()}
+				return
+			}
+
+			value, err := fn(ctx, node)
+			results <- &BroadcastResult{Node: node, Value: value, Err: err}
+		}(node)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	needed := broadcastThreshold(mode, len(nodes))
+	collected := make([]*BroadcastResult, 0, len(nodes))
+	successes := 0
+	for result := range results {
+		collected = append(collected, result)
+		if result.Err == nil {
+			successes++
+		}
+		if successes >= needed {
+			cancel()
+			if mode != All {
+				break
+			}
+		}
+	}
+
+	if successes < needed {
+		if mode == Quorum {
+			return collected, BroadcastQuorumNotReachedError
+		}
+		return collected, BroadcastNoSuccessError
+	}
+	return collected, nil
+}
+
+func broadcastThreshold(mode BroadcastMode, n int) int {
+	switch mode {
+	case FirstSuccess:
+		return 1
+	case Quorum:
+		return n/2 + 1
+	default: // All
+		return n
+	}
+}
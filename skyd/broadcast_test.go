@@ -0,0 +1,81 @@
+package skyd
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func broadcastTestCluster(replicationFactor int, n int) (*Cluster, *NodeGroup) {
+	c := newTestCluster()
+	group := NewNodeGroup("g0")
+	group.ReplicationFactor = replicationFactor
+	c.AddNodeGroup(group)
+	for i := 0; i < n; i++ {
+		c.AddNode(NewNode(intToNodeId(i), "127.0.0.1", uint(9000+i)), group)
+	}
+	return c, group
+}
+
+func TestBroadcastFirstSuccess(t *testing.T) {
+	c, _ := broadcastTestCluster(1, 3)
+
+	results, err := c.Broadcast(func(ctx context.Context, node *Node) (interface{}, error) {
+		if node.id == "a" {
+			return "ok", nil
+		}
+		return nil, errors.New("boom")
+	}, FirstSuccess)
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if len(results) == 0 {
+		t.Fatalf("expected at least one result")
+	}
+}
+
+func TestBroadcastQuorumFailsWithoutMajority(t *testing.T) {
+	c, _ := broadcastTestCluster(1, 3)
+
+	_, err := c.Broadcast(func(ctx context.Context, node *Node) (interface{}, error) {
+		return nil, errors.New("always fails")
+	}, Quorum)
+	if err != BroadcastQuorumNotReachedError {
+		t.Fatalf("expected BroadcastQuorumNotReachedError, got %v", err)
+	}
+}
+
+func TestBroadcastSkipsDeadNodes(t *testing.T) {
+	c, _ := broadcastTestCluster(1, 3)
+	c.setNodeHealth("a", false)
+
+	contacted := map[string]bool{}
+	_, err := c.Broadcast(func(ctx context.Context, node *Node) (interface{}, error) {
+		contacted[node.id] = true
+		return "ok", nil
+	}, All)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if contacted["a"] {
+		t.Fatalf("expected dead node to be skipped by Broadcast")
+	}
+}
+
+func TestBroadcastThreshold(t *testing.T) {
+	cases := []struct {
+		mode BroadcastMode
+		n    int
+		want int
+	}{
+		{FirstSuccess, 5, 1},
+		{Quorum, 5, 3},
+		{Quorum, 4, 3},
+		{All, 5, 5},
+	}
+	for _, c := range cases {
+		if got := broadcastThreshold(c.mode, c.n); got != c.want {
+			t.Fatalf("broadcastThreshold(%v, %d) = %d, want %d", c.mode, c.n, got, c.want)
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"sort"
 	"sync"
+
+	"github.com/hashicorp/memberlist"
 )
 
 //------------------------------------------------------------------------------
@@ -15,6 +17,8 @@ import (
 var NodeGroupRequiredError = errors.New("Node group required")
 var NodeGroupNotFoundError = errors.New("Node group not found")
 var DuplicateNodeError = errors.New("Duplicate node already exists")
+var DuplicateNodeGroupError = errors.New("Duplicate node group already exists")
+var NodeGroupHasTabletsError = errors.New("Node group still owns tablets")
 
 //------------------------------------------------------------------------------
 //
@@ -27,7 +31,27 @@ var DuplicateNodeError = errors.New("Duplicate node already exists")
 // manage a subset of the total dataset.
 type Cluster struct {
 	groups []*NodeGroup
-	mutex  sync.Mutex
+	mutex  sync.RWMutex
+
+	clusterKey string
+	statePath  string
+	memberlist *memberlist.Memberlist
+	delegate   *clusterDelegate
+
+	health      map[string]*NodeHealth
+	healthMutex sync.Mutex
+
+	ring        *Ring
+	tablets     map[string]*Tablet
+	tabletMutex sync.Mutex
+
+	flight      map[string]*call
+	flightMutex sync.Mutex
+
+	// BroadcastParallelism bounds how many nodes Broadcast contacts
+	// concurrently. Defaults to defaultBroadcastParallelism; callers may
+	// raise or lower it to match the size of their cluster.
+	BroadcastParallelism int
 }
 
 //------------------------------------------------------------------------------
@@ -36,11 +60,39 @@ type Cluster struct {
 //
 //------------------------------------------------------------------------------
 
-// Creates a new cluster.
-func NewCluster() *Cluster {
-	return &Cluster{
-		groups: []*NodeGroup{},
+// Creates a new cluster and starts a gossip-based membership subsystem
+// bound to bindAddr. clusterKey is the shared secret used to encrypt
+// memberlist traffic so only nodes in the same Sky cluster can gossip with
+// one another. statePath, if non-empty, is where the last-known topology
+// is persisted so a restarting skyd can rejoin without needing all of
+// seeds to still be reachable. Callers should call Join(seeds) once the
+// cluster is constructed.
+func NewCluster(bindAddr string, clusterKey string, statePath string) (*Cluster, error) {
+	c := &Cluster{
+		groups:               []*NodeGroup{},
+		clusterKey:           clusterKey,
+		statePath:            statePath,
+		health:               make(map[string]*NodeHealth),
+		ring:                 NewRing(),
+		tablets:              make(map[string]*Tablet),
+		flight:               make(map[string]*call),
+		BroadcastParallelism: defaultBroadcastParallelism,
+	}
+	c.delegate = &clusterDelegate{cluster: c}
+
+	config, err := newMemberlistConfig(bindAddr, clusterKey)
+	if err != nil {
+		return nil, err
 	}
+	config.Events = c.delegate
+
+	ml, err := memberlist.Create(config)
+	if err != nil {
+		return nil, err
+	}
+	c.memberlist = ml
+
+	return c, nil
 }
 
 //------------------------------------------------------------------------------
@@ -55,8 +107,8 @@ func NewCluster() *Cluster {
 
 // Finds a group in the cluster by id.
 func (c *Cluster) GetNodeGroup(id string) *NodeGroup {
-	c.mutex.Lock()
-	c.mutex.Unlock()
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
 	return c.getNodeGroup(id)
 }
 
@@ -69,25 +121,48 @@ func (c *Cluster) getNodeGroup(id string) *NodeGroup {
 	return nil
 }
 
-// Adds a group to the cluster.
-func (c *Cluster) AddNodeGroup(group *NodeGroup) {
+// Adds a group to the cluster. Returns DuplicateNodeGroupError if a group
+// with the same id already exists, since two distinct *NodeGroup objects
+// sharing an id would make getNodeGroup's first-match lookup resolve
+// AddNode calls to the wrong one and make Ring.RemoveGroup strip ring
+// points contributed by whichever group is removed out from under the
+// other.
+func (c *Cluster) AddNodeGroup(group *NodeGroup) error {
 	c.mutex.Lock()
-	c.mutex.Unlock()
+	defer c.mutex.Unlock()
+
+	if group == nil {
+		return NodeGroupRequiredError
+	}
+	if c.getNodeGroup(group.id) != nil {
+		return DuplicateNodeGroupError
+	}
+
 	c.groups = append(c.groups, group)
 	sort.Sort(NodeGroups(c.groups))
+	c.ring.AddGroup(group.id)
+	c.seedTablets()
+	return nil
 }
 
-// Removes a group from the cluster.
+// Removes a group from the cluster. The group must have already been
+// drained of its tablets via DrainNodeGroup (or never have owned any); if
+// it still owns tablets the removal is refused so that data isn't
+// silently dropped.
 func (c *Cluster) RemoveNodeGroup(group *NodeGroup) error {
 	c.mutex.Lock()
-	c.mutex.Unlock()
+	defer c.mutex.Unlock()
 
 	if group == nil {
 		return NodeGroupRequiredError
 	}
+	if c.groupOwnsTablets(group.id) {
+		return NodeGroupHasTabletsError
+	}
 	for index, g := range c.groups {
 		if g == group {
 			c.groups = append(c.groups[:index], c.groups[index+1:]...)
+			c.ring.RemoveGroup(group.id)
 			return nil
 		}
 	}
@@ -101,8 +176,8 @@ func (c *Cluster) RemoveNodeGroup(group *NodeGroup) error {
 
 // Retrieves a node and its group from the cluster by id.
 func (c *Cluster) GetNode(id string) (*Node, *NodeGroup) {
-	c.mutex.Lock()
-	c.mutex.Unlock()
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
 	return c.getNode(id)
 }
 
@@ -118,7 +193,7 @@ func (c *Cluster) getNode(id string) (*Node, *NodeGroup) {
 // Adds a node to an existing group in the cluster.
 func (c *Cluster) AddNode(node *Node, group *NodeGroup) error {
 	c.mutex.Lock()
-	c.mutex.Unlock()
+	defer c.mutex.Unlock()
 
 	// Validate node.
 	if node == nil {
@@ -144,7 +219,7 @@ func (c *Cluster) AddNode(node *Node, group *NodeGroup) error {
 // Removes a node from a group in the cluster.
 func (c *Cluster) RemoveNode(node *Node) error {
 	c.mutex.Lock()
-	c.mutex.Unlock()
+	defer c.mutex.Unlock()
 
 	if node == nil {
 		return NodeRequiredError
@@ -162,11 +237,18 @@ func (c *Cluster) RemoveNode(node *Node) error {
 // Serialization
 //--------------------------------------
 
+// Converts the cluster topology to an object that can be easily serialized
+// to JSON, for use by callers outside the skyd package such as the admin
+// HTTP API.
+func (c *Cluster) Serialize() map[string]interface{} {
+	return c.serialize()
+}
+
 // Converts the cluster topology to an object that can be easily serialized
 // to JSON outside the cluster lock.
 func (c *Cluster) serialize() map[string]interface{} {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
 
 	// Serialize groups.
 	groups := []interface{}{}
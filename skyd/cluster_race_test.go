@@ -0,0 +1,88 @@
+package skyd
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// Exercises AddNode/RemoveNode/GetNode/serialize concurrently so that
+// `go test -race` catches any data race on Cluster.groups. Run with:
+//
+//	go test -race ./skyd/... -run TestClusterConcurrentAccess
+func TestClusterConcurrentAccess(t *testing.T) {
+	c := newTestCluster()
+	group := NewNodeGroup("g0")
+	c.AddNodeGroup(group)
+
+	const n = 50
+	nodes := make([]*Node, n)
+	for i := 0; i < n; i++ {
+		nodes[i] = NewNode(fmt.Sprintf("n%d", i), "127.0.0.1", uint(9000+i))
+	}
+
+	var wg sync.WaitGroup
+	for _, node := range nodes {
+		wg.Add(1)
+		go func(node *Node) {
+			defer wg.Done()
+			c.AddNode(node, group)
+		}(node)
+	}
+
+	for i := 0; i < n; i++ {
+		wg.Add(3)
+		go func(i int) {
+			defer wg.Done()
+			c.GetNode(fmt.Sprintf("n%d", i))
+		}(i)
+		go func() {
+			defer wg.Done()
+			c.serialize()
+		}()
+		go func(node *Node) {
+			defer wg.Done()
+			c.RemoveNode(node)
+		}(nodes[i])
+	}
+
+	wg.Wait()
+}
+
+// Exercises AddNodeGroup/RemoveNodeGroup concurrently with Rebalance and
+// DrainNodeGroup so that `go test -race` catches any data race on the
+// Ring and tablet map the sharding support added on top of Cluster.
+func TestClusterRingConcurrentAccess(t *testing.T) {
+	c := newTestCluster()
+
+	const n = 8
+	groups := make([]*NodeGroup, n)
+	for i := 0; i < n; i++ {
+		groups[i] = NewNodeGroup(fmt.Sprintf("g%d", i))
+		c.AddNodeGroup(groups[i])
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(4)
+		go func(i int) {
+			defer wg.Done()
+			extra := NewNodeGroup(fmt.Sprintf("extra-%d", i))
+			c.AddNodeGroup(extra)
+		}(i)
+		go func() {
+			defer wg.Done()
+			c.Rebalance()
+		}()
+		go func(group *NodeGroup) {
+			defer wg.Done()
+			c.DrainNodeGroup(group)
+		}(groups[i])
+		go func() {
+			defer wg.Done()
+			c.groupOwnsTablets("g0")
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,226 @@
+package skyd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+)
+
+//------------------------------------------------------------------------------
+//
+// Typedefs
+//
+//------------------------------------------------------------------------------
+
+// NodeHealth tracks the SWIM-derived health of a single member as reported
+// by memberlist, so the query planner can skip members that have left or
+// failed instead of blindly iterating over every group. A member is either
+// alive or dead here; memberlist's suspect state is not tracked.
+type NodeHealth struct {
+	Alive        bool
+	LastSeen     time.Time
+	SuicideCount int
+}
+
+// memberlistLeaveTimeout bounds how long Shutdown waits for the leave
+// broadcast to propagate to other members before tearing down the local
+// memberlist instance regardless.
+const memberlistLeaveTimeout = 5 * time.Second
+
+// autoDiscoveredGroupId is the node group that gossip-discovered peers are
+// placed into. It must not be derived from the cluster's shared secret,
+// since group ids are returned verbatim by the admin API's serialized
+// topology.
+const autoDiscoveredGroupId = "auto"
+
+// clusterState is the on-disk representation of the last-known topology.
+// It is written after every membership change so that a restarting skyd
+// can rejoin the cluster without needing all of its original seed peers.
+type clusterState struct {
+	Peers []string `json:"peers"`
+}
+
+// clusterDelegate bridges memberlist's join/leave/update notifications into
+// the Cluster's AddNode/RemoveNode calls.
+type clusterDelegate struct {
+	cluster *Cluster
+}
+
+//------------------------------------------------------------------------------
+//
+// Constructor
+//
+//------------------------------------------------------------------------------
+
+// Joins the cluster's memberlist to the given seed peers. Seeds may be
+// omitted on restart if a persisted topology is available at statePath.
+func (c *Cluster) Join(seeds []string) error {
+	if len(seeds) == 0 {
+		seeds = c.loadPersistedPeers()
+	}
+	if len(seeds) == 0 {
+		return nil
+	}
+	_, err := c.memberlist.Join(seeds)
+	return err
+}
+
+// Shutdown gracefully leaves the gossip cluster and releases the bound
+// socket and background goroutines started by NewCluster. Leave is given
+// memberlistLeaveTimeout to broadcast the departure before Shutdown tears
+// the local memberlist instance down regardless, so a slow or partitioned
+// cluster can't block an embedding service's shutdown indefinitely.
+func (c *Cluster) Shutdown() error {
+	if err := c.memberlist.Leave(memberlistLeaveTimeout); err != nil {
+		c.memberlist.Shutdown()
+		return err
+	}
+	return c.memberlist.Shutdown()
+}
+
+//------------------------------------------------------------------------------
+//
+// Methods
+//
+//------------------------------------------------------------------------------
+
+//--------------------------------------
+// memberlist.EventDelegate
+//--------------------------------------
+
+func (d *clusterDelegate) NotifyJoin(n *memberlist.Node) {
+	c := d.cluster
+	group := c.GetNodeGroup(autoDiscoveredGroupId)
+	if group == nil {
+		group = NewNodeGroup(autoDiscoveredGroupId)
+		if c.AddNodeGroup(group) != nil {
+			// Lost the race with another concurrent NotifyJoin that
+			// created autoDiscoveredGroupId first; use theirs.
+			group = c.GetNodeGroup(autoDiscoveredGroupId)
+		}
+	}
+	c.AddNode(NewNode(n.Name, n.Addr.String(), uint(n.Port)), group)
+	c.setNodeHealth(n.Name, true)
+	c.persistState()
+}
+
+func (d *clusterDelegate) NotifyLeave(n *memberlist.Node) {
+	c := d.cluster
+	if node, _ := c.GetNode(n.Name); node != nil {
+		c.RemoveNode(node)
+	}
+	c.setNodeHealth(n.Name, false)
+	c.persistState()
+}
+
+func (d *clusterDelegate) NotifyUpdate(n *memberlist.Node) {
+	d.cluster.setNodeHealth(n.Name, true)
+}
+
+//--------------------------------------
+// Health
+//--------------------------------------
+
+// Returns the last-known SWIM health for a node, or nil if the node has
+// never been observed.
+func (c *Cluster) NodeHealth(id string) *NodeHealth {
+	c.healthMutex.Lock()
+	defer c.healthMutex.Unlock()
+	return c.health[id]
+}
+
+func (c *Cluster) setNodeHealth(id string, alive bool) {
+	c.healthMutex.Lock()
+	defer c.healthMutex.Unlock()
+	h := c.health[id]
+	if h == nil {
+		h = &NodeHealth{}
+		c.health[id] = h
+	}
+	if !alive {
+		h.SuicideCount++
+	}
+	h.Alive = alive
+	h.LastSeen = time.Now()
+}
+
+//--------------------------------------
+// Persistence
+//--------------------------------------
+
+// Persists the current topology to disk so that a restarting skyd can
+// rejoin the cluster using its last-known peers instead of requiring all
+// of its original seeds to still be reachable.
+func (c *Cluster) persistState() error {
+	if c.statePath == "" {
+		return nil
+	}
+
+	c.mutex.RLock()
+	peers := []string{}
+	for _, group := range c.groups {
+		for _, node := range group.nodes {
+			peers = append(peers, fmt.Sprintf("%s:%d", node.host, node.port))
+		}
+	}
+	c.mutex.RUnlock()
+
+	data, err := json.Marshal(clusterState{Peers: peers})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.statePath, data, 0644)
+}
+
+func (c *Cluster) loadPersistedPeers() []string {
+	if c.statePath == "" {
+		return nil
+	}
+	data, err := ioutil.ReadFile(c.statePath)
+	if err != nil {
+		return nil
+	}
+	var state clusterState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil
+	}
+	return state.Peers
+}
+
+//--------------------------------------
+// memberlist configuration
+//--------------------------------------
+
+func newMemberlistConfig(bindAddr string, clusterKey string) (*memberlist.Config, error) {
+	host, port, err := splitBindAddr(bindAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	config := memberlist.DefaultLANConfig()
+	config.Name = fmt.Sprintf("%s-%d", host, os.Getpid())
+	config.BindAddr = host
+	config.BindPort = port
+	if clusterKey != "" {
+		config.SecretKey = []byte(clusterKey)
+	}
+	return config, nil
+}
+
+func splitBindAddr(bindAddr string) (string, int, error) {
+	host, portStr, err := net.SplitHostPort(bindAddr)
+	if err != nil {
+		return "", 0, err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return "", 0, err
+	}
+	return host, port, nil
+}
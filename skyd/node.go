@@ -0,0 +1,101 @@
+package skyd
+
+import (
+	"errors"
+)
+
+//------------------------------------------------------------------------------
+//
+// Globals
+//
+//------------------------------------------------------------------------------
+
+var NodeRequiredError = errors.New("Node required")
+var NodeNotFoundError = errors.New("Node not found")
+
+//------------------------------------------------------------------------------
+//
+// Typedefs
+//
+//------------------------------------------------------------------------------
+
+// A node represents a single server within a node group. Rack and DC
+// identify the node's physical placement so that a group's
+// PlacementPolicy can spread replicas across failure domains.
+type Node struct {
+	id   string
+	host string
+	port uint
+	rack string
+	dc   string
+}
+
+//------------------------------------------------------------------------------
+//
+// Constructor
+//
+//------------------------------------------------------------------------------
+
+// Creates a new node.
+func NewNode(id string, host string, port uint) *Node {
+	return &Node{
+		id:   id,
+		host: host,
+		port: port,
+	}
+}
+
+// Creates a new node with an explicit rack and datacenter, for use with a
+// RackAware placement policy.
+func NewNodeWithPlacement(id string, host string, port uint, rack string, dc string) *Node {
+	n := NewNode(id, host, port)
+	n.rack = rack
+	n.dc = dc
+	return n
+}
+
+//------------------------------------------------------------------------------
+//
+// Methods
+//
+//------------------------------------------------------------------------------
+
+// Retrieves the identifier for the node.
+func (n *Node) Id() string {
+	return n.id
+}
+
+// Retrieves the hostname the node listens on.
+func (n *Node) Host() string {
+	return n.host
+}
+
+// Retrieves the port the node listens on.
+func (n *Node) Port() uint {
+	return n.port
+}
+
+// Retrieves the rack the node is physically placed in.
+func (n *Node) Rack() string {
+	return n.rack
+}
+
+// Retrieves the datacenter the node is physically placed in.
+func (n *Node) DC() string {
+	return n.dc
+}
+
+//--------------------------------------
+// Serialization
+//--------------------------------------
+
+// Converts the node to an object that can be easily serialized to JSON.
+func (n *Node) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"id":   n.id,
+		"host": n.host,
+		"port": n.port,
+		"rack": n.rack,
+		"dc":   n.dc,
+	}
+}
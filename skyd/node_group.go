@@ -0,0 +1,147 @@
+package skyd
+
+import (
+	"errors"
+)
+
+//------------------------------------------------------------------------------
+//
+// Globals
+//
+//------------------------------------------------------------------------------
+
+var RackPlacementViolationError = errors.New("Node would violate the group's rack-aware placement policy")
+
+//------------------------------------------------------------------------------
+//
+// Typedefs
+//
+//------------------------------------------------------------------------------
+
+// PlacementPolicy constrains which nodes may be added to a node group.
+type PlacementPolicy int
+
+const (
+	// Any places no constraint on node placement.
+	Any PlacementPolicy = iota
+
+	// RackAware refuses to add a node whose rack is already represented in
+	// the group, so that replicas are spread across failure domains.
+	RackAware
+)
+
+// A node group is a set of nodes that all manage the same subset of the
+// total dataset. ReplicationFactor controls how many of the group's nodes
+// hold a copy of any given object, and Policy constrains how those nodes
+// may be placed.
+type NodeGroup struct {
+	id                string
+	nodes             []*Node
+	ReplicationFactor int
+	Policy            PlacementPolicy
+}
+
+// NodeGroups represents a sortable collection of node groups, ordered by id.
+type NodeGroups []*NodeGroup
+
+//------------------------------------------------------------------------------
+//
+// Constructor
+//
+//------------------------------------------------------------------------------
+
+// Creates a new node group with a replication factor of 1 and no
+// placement constraints.
+func NewNodeGroup(id string) *NodeGroup {
+	return &NodeGroup{
+		id:                id,
+		nodes:             make([]*Node, 0),
+		ReplicationFactor: 1,
+		Policy:            Any,
+	}
+}
+
+//------------------------------------------------------------------------------
+//
+// Methods
+//
+//------------------------------------------------------------------------------
+
+// Retrieves the identifier for the node group.
+func (g *NodeGroup) Id() string {
+	return g.id
+}
+
+// Retrieves the nodes within the group.
+func (g *NodeGroup) Nodes() []*Node {
+	return g.nodes
+}
+
+// Finds a node in the group by id.
+func (g *NodeGroup) getNode(id string) *Node {
+	for _, node := range g.nodes {
+		if node.id == id {
+			return node
+		}
+	}
+	return nil
+}
+
+// Adds a node to the group, enforcing the group's placement policy.
+func (g *NodeGroup) addNode(node *Node) error {
+	if n := g.getNode(node.id); n != nil {
+		return DuplicateNodeError
+	}
+	if g.Policy == RackAware && node.rack != "" {
+		for _, n := range g.nodes {
+			if n.rack == node.rack {
+				return RackPlacementViolationError
+			}
+		}
+	}
+	g.nodes = append(g.nodes, node)
+	return nil
+}
+
+// Removes a node from the group.
+func (g *NodeGroup) removeNode(node *Node) error {
+	for index, n := range g.nodes {
+		if n == node {
+			g.nodes = append(g.nodes[:index], g.nodes[index+1:]...)
+			return nil
+		}
+	}
+	return NodeNotFoundError
+}
+
+//--------------------------------------
+// Serialization
+//--------------------------------------
+
+// Converts the node group to an object that can be easily serialized to JSON.
+func (g *NodeGroup) Serialize() map[string]interface{} {
+	nodes := []interface{}{}
+	for _, node := range g.nodes {
+		nodes = append(nodes, node.Serialize())
+	}
+	return map[string]interface{}{
+		"id":    g.id,
+		"nodes": nodes,
+	}
+}
+
+//--------------------------------------
+// Sort.Interface
+//--------------------------------------
+
+func (s NodeGroups) Len() int {
+	return len(s)
+}
+
+func (s NodeGroups) Less(i, j int) bool {
+	return s[i].id < s[j].id
+}
+
+func (s NodeGroups) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
@@ -0,0 +1,84 @@
+package skyd
+
+//------------------------------------------------------------------------------
+//
+// Typedefs
+//
+//------------------------------------------------------------------------------
+
+// Consistency controls how many replicas a read must be satisfied from.
+type Consistency int
+
+const (
+	// ONE is satisfied by a single replica.
+	ONE Consistency = iota
+
+	// QUORUM is satisfied by a strict majority of the write replica set.
+	QUORUM
+
+	// ALL requires every replica in the write replica set.
+	ALL
+)
+
+//------------------------------------------------------------------------------
+//
+// Methods
+//
+//------------------------------------------------------------------------------
+
+// Returns the ordered set of nodes that should receive a write for
+// objectId, as determined by the owning group's ring placement and
+// ReplicationFactor. Nodes that gossip health has marked dead are skipped
+// so that a write doesn't get routed at a member known to be down.
+func (c *Cluster) WriteReplicas(objectId string) []*Node {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	group := c.getNodeGroup(c.ring.Get(objectId))
+	if group == nil {
+		return nil
+	}
+
+	candidates := c.aliveNodes(group.nodes)
+	n := group.ReplicationFactor
+	if n <= 0 || n > len(candidates) {
+		n = len(candidates)
+	}
+	return append([]*Node{}, candidates[:n]...)
+}
+
+// Filters nodes down to those that aren't known to be dead. A node with no
+// recorded health (e.g. added directly via the admin API rather than
+// discovered through gossip) is assumed alive.
+func (c *Cluster) aliveNodes(nodes []*Node) []*Node {
+	alive := make([]*Node, 0, len(nodes))
+	for _, node := range nodes {
+		if health := c.NodeHealth(node.id); health != nil && !health.Alive {
+			continue
+		}
+		alive = append(alive, node)
+	}
+	return alive
+}
+
+// Returns the subset of objectId's write replicas that must respond for a
+// read at the given consistency level.
+func (c *Cluster) ReadReplicas(objectId string, consistency Consistency) []*Node {
+	replicas := c.WriteReplicas(objectId)
+
+	switch consistency {
+	case ONE:
+		if len(replicas) > 1 {
+			return replicas[:1]
+		}
+		return replicas
+	case QUORUM:
+		quorum := len(replicas)/2 + 1
+		if quorum > len(replicas) {
+			quorum = len(replicas)
+		}
+		return replicas[:quorum]
+	default: // ALL
+		return replicas
+	}
+}
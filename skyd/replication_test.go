@@ -0,0 +1,74 @@
+package skyd
+
+import (
+	"testing"
+)
+
+func TestRackAwarePlacementRefusesSameRack(t *testing.T) {
+	c := newTestCluster()
+	group := NewNodeGroup("g0")
+	group.Policy = RackAware
+	c.AddNodeGroup(group)
+
+	if err := c.AddNode(NewNodeWithPlacement("n0", "127.0.0.1", 9000, "r1", "dc1"), group); err != nil {
+		t.Fatalf("unexpected error adding first node: %v", err)
+	}
+	if err := c.AddNode(NewNodeWithPlacement("n1", "127.0.0.1", 9001, "r1", "dc1"), group); err != RackPlacementViolationError {
+		t.Fatalf("expected RackPlacementViolationError, got %v", err)
+	}
+	if err := c.AddNode(NewNodeWithPlacement("n2", "127.0.0.1", 9002, "r2", "dc1"), group); err != nil {
+		t.Fatalf("unexpected error adding node in a different rack: %v", err)
+	}
+}
+
+func TestWriteReplicasRespectsReplicationFactorAndSkipsDeadNodes(t *testing.T) {
+	c := newTestCluster()
+	group := NewNodeGroup("g0")
+	group.ReplicationFactor = 2
+	c.AddNodeGroup(group)
+
+	for i := 0; i < 3; i++ {
+		node := NewNode(intToNodeId(i), "127.0.0.1", uint(9000+i))
+		if err := c.AddNode(node, group); err != nil {
+			t.Fatalf("AddNode returned error: %v", err)
+		}
+	}
+
+	replicas := c.WriteReplicas("some-object-id")
+	if len(replicas) != 2 {
+		t.Fatalf("expected 2 write replicas, got %d", len(replicas))
+	}
+
+	deadId := replicas[0].id
+	c.setNodeHealth(deadId, false)
+	replicas = c.WriteReplicas("some-object-id")
+	for _, node := range replicas {
+		if node.id == deadId {
+			t.Fatalf("expected dead node to be excluded from write replicas")
+		}
+	}
+}
+
+func TestReadReplicasQuorum(t *testing.T) {
+	c := newTestCluster()
+	group := NewNodeGroup("g0")
+	group.ReplicationFactor = 3
+	c.AddNodeGroup(group)
+	for i := 0; i < 3; i++ {
+		c.AddNode(NewNode(intToNodeId(i), "127.0.0.1", uint(9000+i)), group)
+	}
+
+	if got := len(c.ReadReplicas("some-object-id", ONE)); got != 1 {
+		t.Fatalf("ONE: expected 1 replica, got %d", got)
+	}
+	if got := len(c.ReadReplicas("some-object-id", QUORUM)); got != 2 {
+		t.Fatalf("QUORUM: expected 2 replicas, got %d", got)
+	}
+	if got := len(c.ReadReplicas("some-object-id", ALL)); got != 3 {
+		t.Fatalf("ALL: expected 3 replicas, got %d", got)
+	}
+}
+
+func intToNodeId(i int) string {
+	return string(rune('a' + i))
+}
@@ -0,0 +1,169 @@
+package skyd
+
+import (
+	"hash/crc32"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+//------------------------------------------------------------------------------
+//
+// Globals
+//
+//------------------------------------------------------------------------------
+
+// The number of virtual replicas placed on the ring per node group. More
+// replicas smooth out the distribution of tablets across groups at the
+// cost of a larger ring to search.
+const ringVirtualReplicas = 160
+
+// RingPartitions is the fixed number of tablets the keyspace is split
+// into. Every object ID falls into exactly one partition, so a cluster's
+// tablet ownership can be fully enumerated rather than only known for
+// object IDs some caller happened to move explicitly.
+const RingPartitions = 1024
+
+//------------------------------------------------------------------------------
+//
+// Typedefs
+//
+//------------------------------------------------------------------------------
+
+// Ring is a consistent hash ring over a cluster's node groups. It maps
+// tablets to the group responsible for owning them, and is rebuilt
+// whenever a group is added or removed so that only the tablets adjacent
+// to the change need to move.
+type Ring struct {
+	mutex    sync.RWMutex
+	points   []uint32
+	owners   map[uint32]string
+	replicas int
+}
+
+// Tablet is a single shard of the keyspace. Every object ID hashes to
+// exactly one tablet, and every tablet is owned by exactly one node group
+// at a time.
+type Tablet struct {
+	Id      string
+	GroupId string
+}
+
+// MoveTask represents a background copy of a tablet from one node group to
+// another, issued while rebalancing or draining a group.
+type MoveTask struct {
+	Tablet   *Tablet
+	SrcGroup string
+	DstGroup string
+	Done     bool
+	Err      error
+}
+
+//------------------------------------------------------------------------------
+//
+// Constructor
+//
+//------------------------------------------------------------------------------
+
+// Creates a new, empty consistent hash ring.
+func NewRing() *Ring {
+	return &Ring{
+		points:   []uint32{},
+		owners:   make(map[uint32]string),
+		replicas: ringVirtualReplicas,
+	}
+}
+
+//------------------------------------------------------------------------------
+//
+// Methods
+//
+//------------------------------------------------------------------------------
+
+// Adds a node group's virtual replicas to the ring.
+func (r *Ring) AddGroup(groupId string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i := 0; i < r.replicas; i++ {
+		point := ringHash(groupId + "#" + strconv.Itoa(i))
+		r.points = append(r.points, point)
+		r.owners[point] = groupId
+	}
+	sort.Slice(r.points, func(i, j int) bool { return r.points[i] < r.points[j] })
+}
+
+// Removes a node group's virtual replicas from the ring.
+func (r *Ring) RemoveGroup(groupId string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	points := r.points[:0]
+	for _, point := range r.points {
+		if owner := r.owners[point]; owner == groupId {
+			delete(r.owners, point)
+			continue
+		}
+		points = append(points, point)
+	}
+	r.points = points
+}
+
+// Returns the id of the node group whose ring point is the first at or
+// after objectId's hash. For a tablet id this is the tablet's ideal owner;
+// any other key is hashed onto the ring as is, not mapped to its tablet
+// first.
+func (r *Ring) Get(objectId string) string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.get(objectId)
+}
+
+func (r *Ring) get(objectId string) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+
+	point := ringHash(objectId)
+	index := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= point })
+	if index == len(r.points) {
+		index = 0
+	}
+	return r.owners[r.points[index]]
+}
+
+// Returns the id of the node group whose ring point is the first at or
+// after objectId's hash, skipping any replica points belonging to
+// excludeGroupId. Used to find a drain
+// destination for a group that is being removed but still owns the ring
+// entries being searched.
+func (r *Ring) GetExcluding(objectId string, excludeGroupId string) string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if len(r.points) == 0 {
+		return ""
+	}
+
+	point := ringHash(objectId)
+	index := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= point })
+	for i := 0; i < len(r.points); i++ {
+		candidate := r.points[(index+i)%len(r.points)]
+		if owner := r.owners[candidate]; owner != excludeGroupId {
+			return owner
+		}
+	}
+	return ""
+}
+
+func ringHash(key string) uint32 {
+	return crc32.ChecksumIEEE([]byte(key))
+}
+
+// Maps an object ID down to one of the cluster's fixed RingPartitions
+// tablet ids, so that every possible object ID resolves to one of a
+// finite, enumerable set of tablets instead of an unbounded one keyed by
+// the raw object ID.
+func partitionFor(objectId string) string {
+	return "tablet-" + strconv.Itoa(int(ringHash(objectId)%RingPartitions))
+}
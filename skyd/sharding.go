@@ -0,0 +1,156 @@
+package skyd
+
+import (
+	"errors"
+	"strconv"
+)
+
+//------------------------------------------------------------------------------
+//
+// Globals
+//
+//------------------------------------------------------------------------------
+
+var TabletOwnerMismatchError = errors.New("Tablet is not owned by the source group")
+
+//------------------------------------------------------------------------------
+//
+// Methods
+//
+//------------------------------------------------------------------------------
+
+//--------------------------------------
+// Tablets
+//--------------------------------------
+
+// Moves objectId's tablet from one node group to another. The caller is
+// responsible for streaming the underlying shard data; MoveTablet only
+// updates ownership once that transfer has completed, so callers should
+// copy the data for objectId's tablet to dstGroup before calling this.
+//
+// objectId is mapped down to one of the cluster's fixed RingPartitions
+// tablets rather than tracked per-object, so that ownership of every
+// tablet is always known -- not only for object IDs some caller happened
+// to move explicitly -- and RemoveNodeGroup's "still owns tablets" guard
+// can't be bypassed simply by never calling MoveTablet for a given key.
+func (c *Cluster) MoveTablet(objectId string, srcGroup string, dstGroup string) error {
+	return c.moveTablet(partitionFor(objectId), srcGroup, dstGroup)
+}
+
+// moveTablet reassigns an already-resolved tablet id (as produced by
+// partitionFor or stored on a Tablet) rather than an arbitrary object ID,
+// so that callers operating on a Tablet they already looked up -- like
+// DrainNodeGroup and Rebalance -- don't have it re-hashed into a
+// different tablet out from under them.
+func (c *Cluster) moveTablet(id string, srcGroup string, dstGroup string) error {
+	c.tabletMutex.Lock()
+	defer c.tabletMutex.Unlock()
+
+	tablet := c.tablets[id]
+	if tablet == nil {
+		tablet = &Tablet{Id: id, GroupId: srcGroup}
+		c.tablets[id] = tablet
+	}
+	if tablet.GroupId != srcGroup {
+		return TabletOwnerMismatchError
+	}
+
+	tablet.GroupId = dstGroup
+	return nil
+}
+
+// Seeds ownership for any of the cluster's fixed tablets that aren't yet
+// tracked, assigning them to their current ring owner. Called whenever a
+// group is added, so that the first group to join the ring owns every
+// tablet at once, rather than only once a caller happens to touch one of
+// its keys via MoveTablet. Tablets that are already tracked keep their
+// owner; a group added later owns none of them until Rebalance or
+// MoveTablet reassigns them.
+func (c *Cluster) seedTablets() {
+	c.tabletMutex.Lock()
+	defer c.tabletMutex.Unlock()
+
+	for i := 0; i < RingPartitions; i++ {
+		id := "tablet-" + strconv.Itoa(i)
+		if _, ok := c.tablets[id]; ok {
+			continue
+		}
+		if owner := c.ring.Get(id); owner != "" {
+			c.tablets[id] = &Tablet{Id: id, GroupId: owner}
+		}
+	}
+}
+
+// Reassigns every tablet owned by group to its next-best owner on the
+// ring, so that RemoveNodeGroup can subsequently succeed. Only ownership
+// changes; no shard data is copied. A tablet for which the ring holds no
+// other group is left with group, and RemoveNodeGroup still refuses it.
+func (c *Cluster) DrainNodeGroup(group *NodeGroup) error {
+	for _, tablet := range c.tabletsOwnedBy(group.id) {
+		dst := c.ring.GetExcluding(tablet.Id, group.id)
+		if dst == "" {
+			continue
+		}
+		if err := c.moveTablet(tablet.Id, group.id, dst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Cluster) tabletsOwnedBy(groupId string) []*Tablet {
+	c.tabletMutex.Lock()
+	defer c.tabletMutex.Unlock()
+
+	owned := []*Tablet{}
+	for _, tablet := range c.tablets {
+		if tablet.GroupId == groupId {
+			owned = append(owned, tablet)
+		}
+	}
+	return owned
+}
+
+func (c *Cluster) groupOwnsTablets(groupId string) bool {
+	return len(c.tabletsOwnedBy(groupId)) > 0
+}
+
+//--------------------------------------
+// Rebalancing
+//--------------------------------------
+
+// Computes the ideal tablet ownership for each group based on the ring and
+// issues a MoveTask for every tablet that is currently owned by the wrong
+// group, bringing actual ownership back in line with the ring. The
+// returned tasks are issued synchronously but represent the same transfer
+// that a background copy worker would perform for a real shard.
+func (c *Cluster) Rebalance() []*MoveTask {
+	// Snapshot id/owner pairs by value while holding the lock, rather than
+	// keeping the *Tablet pointers themselves: moveTablet below mutates
+	// those same Tablets from other goroutines, so reading tablet.GroupId
+	// again after releasing the lock would race.
+	c.tabletMutex.Lock()
+	type snapshot struct {
+		id      string
+		groupId string
+	}
+	snapshots := make([]snapshot, 0, len(c.tablets))
+	for id, tablet := range c.tablets {
+		snapshots = append(snapshots, snapshot{id: id, groupId: tablet.GroupId})
+	}
+	c.tabletMutex.Unlock()
+
+	tasks := []*MoveTask{}
+	for _, s := range snapshots {
+		ideal := c.ring.Get(s.id)
+		if ideal == "" || ideal == s.groupId {
+			continue
+		}
+
+		task := &MoveTask{Tablet: &Tablet{Id: s.id, GroupId: s.groupId}, SrcGroup: s.groupId, DstGroup: ideal}
+		task.Err = c.moveTablet(s.id, s.groupId, ideal)
+		task.Done = task.Err == nil
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
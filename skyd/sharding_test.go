@@ -0,0 +1,66 @@
+package skyd
+
+import (
+	"testing"
+)
+
+func newTestCluster() *Cluster {
+	return &Cluster{
+		groups:  []*NodeGroup{},
+		ring:    NewRing(),
+		tablets: make(map[string]*Tablet),
+		flight:  make(map[string]*call),
+		health:  make(map[string]*NodeHealth),
+	}
+}
+
+// A sole group owns every tablet in the keyspace as soon as it joins the
+// ring, even if no key was ever explicitly passed through MoveTablet, so
+// RemoveNodeGroup must refuse to remove it.
+func TestRemoveNodeGroupRefusesWhileOwningTablets(t *testing.T) {
+	c := newTestCluster()
+	group := NewNodeGroup("g0")
+	c.AddNodeGroup(group)
+
+	if err := c.RemoveNodeGroup(group); err != NodeGroupHasTabletsError {
+		t.Fatalf("expected NodeGroupHasTabletsError, got %v", err)
+	}
+}
+
+// Once a second group exists, draining moves every tablet off of the
+// group being removed and RemoveNodeGroup succeeds.
+func TestDrainNodeGroupThenRemove(t *testing.T) {
+	c := newTestCluster()
+	g0 := NewNodeGroup("g0")
+	g1 := NewNodeGroup("g1")
+	c.AddNodeGroup(g0)
+	c.AddNodeGroup(g1)
+
+	if err := c.DrainNodeGroup(g0); err != nil {
+		t.Fatalf("DrainNodeGroup returned error: %v", err)
+	}
+	if c.groupOwnsTablets(g0.id) {
+		t.Fatalf("expected g0 to own no tablets after draining")
+	}
+	if err := c.RemoveNodeGroup(g0); err != nil {
+		t.Fatalf("RemoveNodeGroup returned error after drain: %v", err)
+	}
+}
+
+// Rebalance brings every tracked tablet back in line with the ring's
+// current placement.
+func TestRebalanceMatchesRing(t *testing.T) {
+	c := newTestCluster()
+	g0 := NewNodeGroup("g0")
+	c.AddNodeGroup(g0)
+	g1 := NewNodeGroup("g1")
+	c.AddNodeGroup(g1)
+
+	c.Rebalance()
+
+	for id, tablet := range c.tablets {
+		if want := c.ring.Get(id); tablet.GroupId != want {
+			t.Fatalf("tablet %s owned by %s, want %s", id, tablet.GroupId, want)
+		}
+	}
+}
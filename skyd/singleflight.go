@@ -0,0 +1,55 @@
+package skyd
+
+import (
+	"sync"
+)
+
+//------------------------------------------------------------------------------
+//
+// Typedefs
+//
+//------------------------------------------------------------------------------
+
+// call is an in-flight or completed Do call, shared by every goroutine
+// that asks for the same key while it is running.
+type call struct {
+	wg  sync.WaitGroup
+	val interface{}
+	err error
+}
+
+//------------------------------------------------------------------------------
+//
+// Methods
+//
+//------------------------------------------------------------------------------
+
+// Do executes fn, making sure only one execution is in flight for a given
+// key at a time. If a duplicate call comes in while one is already
+// running, the duplicate waits for the original to complete and receives
+// its result rather than triggering a second execution. This is used to
+// suppress thundering herds of servlet requests that all try to rebuild
+// the same topology-derived state (e.g. "schema:"+groupId) during a
+// membership change.
+func (c *Cluster) Do(key string, fn func() (interface{}, error)) (interface{}, error) {
+	c.flightMutex.Lock()
+	if call, ok := c.flight[key]; ok {
+		c.flightMutex.Unlock()
+		call.wg.Wait()
+		return call.val, call.err
+	}
+
+	call := new(call)
+	call.wg.Add(1)
+	c.flight[key] = call
+	c.flightMutex.Unlock()
+
+	call.val, call.err = fn()
+	call.wg.Done()
+
+	c.flightMutex.Lock()
+	delete(c.flight, key)
+	c.flightMutex.Unlock()
+
+	return call.val, call.err
+}
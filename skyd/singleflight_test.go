@@ -0,0 +1,83 @@
+package skyd
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDoSuppressesDuplicateConcurrentCalls(t *testing.T) {
+	c := newTestCluster()
+
+	var calls int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fn := func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		close(started)
+		<-release
+		return "schema-value", nil
+	}
+
+	const n = 20
+	results := make([]interface{}, n)
+	var wg sync.WaitGroup
+
+	do := func(i int, reached *sync.WaitGroup) {
+		defer wg.Done()
+		if reached != nil {
+			reached.Done()
+		}
+		val, err := c.Do("schema:g0", fn)
+		if err != nil {
+			t.Errorf("Do returned error: %v", err)
+		}
+		results[i] = val
+	}
+
+	// Launch the first call alone and wait for fn to actually be running
+	// before launching the rest, so they are guaranteed to find the call
+	// already in flight rather than racing to start their own.
+	wg.Add(1)
+	go do(0, nil)
+	<-started
+
+	// Don't release the blocked call until every duplicate has reached
+	// Do, otherwise a duplicate the scheduler hasn't gotten to yet could
+	// still be waiting when release fires, see the first call's entry
+	// removed, and mistakenly start a second call of its own.
+	var reachedDo sync.WaitGroup
+	reachedDo.Add(n - 1)
+	for i := 1; i < n; i++ {
+		wg.Add(1)
+		go do(i, &reachedDo)
+	}
+	reachedDo.Wait()
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("expected exactly 1 underlying call, got %d", calls)
+	}
+	for i, v := range results {
+		if v != "schema-value" {
+			t.Fatalf("result %d = %v, want schema-value", i, v)
+		}
+	}
+}
+
+func TestDoRunsAgainAfterPriorCallCompletes(t *testing.T) {
+	c := newTestCluster()
+
+	var calls int32
+	for i := 0; i < 3; i++ {
+		c.Do("schema:g0", func() (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			return nil, nil
+		})
+	}
+
+	if calls != 3 {
+		t.Fatalf("expected 3 sequential calls, got %d", calls)
+	}
+}